@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	batchParallel int
+	batchFailFast bool
+)
+
+func init() {
+	flag.IntVar(&batchParallel, "parallel", 4, "Number of concurrent batch workers")
+	flag.BoolVar(&batchFailFast, "fail-fast", false, "Stop dispatching new jobs after the first error")
+}
+
+type batchJob struct {
+	Seq       int                    `json:"seq"`
+	Cmd       string                 `json:"cmd"`
+	Query     string                 `json:"query,omitempty"`
+	Tool      string                 `json:"tool,omitempty"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	TimeoutMs int                    `json:"timeout_ms,omitempty"`
+}
+
+type batchResult struct {
+	Seq        int                    `json:"seq"`
+	Cmd        string                 `json:"cmd"`
+	OK         bool                   `json:"ok"`
+	Response   map[string]interface{} `json:"response,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	DurationMs int64                  `json:"duration_ms"`
+}
+
+func handleBatch() {
+	args := flag.Args()
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: mimo batch <file.ndjson|->")
+		os.Exit(1)
+	}
+
+	jobs, err := loadBatchJobs(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	results := runBatch(jobs)
+	printBatchSummary(results)
+}
+
+func loadBatchJobs(path string) ([]batchJob, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var jobs []batchJob
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	seq := 0
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var job batchJob
+		if err := json.Unmarshal(line, &job); err != nil {
+			return nil, fmt.Errorf("invalid job on line %d: %w", lineNo, err)
+		}
+		job.Seq = seq
+		jobs = append(jobs, job)
+		seq++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+const batchSkippedMsg = "skipped: fail-fast triggered by an earlier job"
+
+func runBatch(jobs []batchJob) []batchResult {
+	jobCh := make(chan batchJob)
+	results := make([]batchResult, len(jobs))
+
+	var stop int32
+	var stdoutMu sync.Mutex
+	var wg sync.WaitGroup
+
+	// record is the single place a result is ever finalized, whether a
+	// worker actually ran the job or the job never got sent at all because
+	// --fail-fast tripped first - every job gets exactly one NDJSON line
+	// and one results[] slot this way.
+	record := func(result batchResult) {
+		results[result.Seq] = result
+		line, err := json.Marshal(result)
+		if err == nil {
+			stdoutMu.Lock()
+			fmt.Println(string(line))
+			stdoutMu.Unlock()
+		}
+	}
+
+	workers := batchParallel
+	if workers < 1 {
+		workers = 1
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				if batchFailFast && atomic.LoadInt32(&stop) != 0 {
+					record(batchResult{Seq: job.Seq, Cmd: job.Cmd, Error: batchSkippedMsg})
+					continue
+				}
+
+				result := runBatchJob(job)
+				if !result.OK && batchFailFast {
+					atomic.StoreInt32(&stop, 1)
+				}
+				record(result)
+			}
+		}()
+	}
+
+	for i, job := range jobs {
+		if batchFailFast && atomic.LoadInt32(&stop) != 0 {
+			for _, skipped := range jobs[i:] {
+				record(batchResult{Seq: skipped.Seq, Cmd: skipped.Cmd, Error: batchSkippedMsg})
+			}
+			break
+		}
+		jobCh <- job
+	}
+	close(jobCh)
+	wg.Wait()
+
+	return results
+}
+
+func runBatchJob(job batchJob) batchResult {
+	start := time.Now()
+	result := batchResult{Seq: job.Seq, Cmd: job.Cmd}
+
+	jobTimeout := timeout
+	if job.TimeoutMs > 0 {
+		jobTimeout = job.TimeoutMs
+	}
+
+	var resp map[string]interface{}
+	var err error
+
+	switch job.Cmd {
+	case "ask":
+		resp, err = makeRequestWithTimeout("POST", "/v1/mimo/ask", map[string]interface{}{
+			"query":      job.Query,
+			"timeout_ms": jobTimeout,
+		}, jobTimeout)
+	case "run":
+		resp, err = makeRequestWithTimeout("POST", "/v1/mimo/tool", map[string]interface{}{
+			"tool":      job.Tool,
+			"arguments": job.Arguments,
+		}, jobTimeout)
+	default:
+		err = fmt.Errorf("unknown batch cmd: %q", job.Cmd)
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.OK = true
+	result.Response = resp
+	return result
+}
+
+func printBatchSummary(results []batchResult) {
+	var ok, failed int
+	buckets := map[string]int{"<100ms": 0, "<500ms": 0, "<1s": 0, "<5s": 0, ">=5s": 0}
+
+	for _, r := range results {
+		if r.OK {
+			ok++
+		} else {
+			failed++
+		}
+
+		switch {
+		case r.DurationMs < 100:
+			buckets["<100ms"]++
+		case r.DurationMs < 500:
+			buckets["<500ms"]++
+		case r.DurationMs < 1000:
+			buckets["<1s"]++
+		case r.DurationMs < 5000:
+			buckets["<5s"]++
+		default:
+			buckets[">=5s"]++
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "batch: %d ok, %d error, %d total | durations: <100ms=%d <500ms=%d <1s=%d <5s=%d >=5s=%d\n",
+		ok, failed, len(results),
+		buckets["<100ms"], buckets["<500ms"], buckets["<1s"], buckets["<5s"], buckets[">=5s"])
+}
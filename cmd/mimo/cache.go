@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	cacheMode string
+	cacheTTL  time.Duration
+	offline   bool
+)
+
+func init() {
+	flag.StringVar(&cacheMode, "cache", "off", "Response cache mode: off,read,write,rw")
+	flag.DurationVar(&cacheTTL, "cache-ttl", 10*time.Minute, "Max age of a cached response before it's considered stale")
+	flag.BoolVar(&offline, "offline", false, "Cache-only mode: never hit the network, error on a cache miss")
+}
+
+// cacheRecord is the on-disk shape of one cached response, keyed by the
+// sha256 of (method, path, canonicalized payload).
+type cacheRecord struct {
+	Key      string                 `json:"key"`
+	Method   string                 `json:"method"`
+	Path     string                 `json:"path"`
+	StoredAt time.Time              `json:"stored_at"`
+	Response map[string]interface{} `json:"response"`
+}
+
+func cacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "mimo")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".cache", "mimo")
+	}
+	return filepath.Join(home, ".cache", "mimo")
+}
+
+func cachePath(key string) string {
+	return filepath.Join(cacheDir(), key+".json")
+}
+
+func computeCacheKey(method, path string, payload interface{}) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", method, path)
+	h.Write(canonicalJSON(payload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// canonicalJSON marshals v with map keys sorted at every level, so the same
+// logical payload always hashes to the same cache key regardless of how the
+// map was built.
+func canonicalJSON(v interface{}) []byte {
+	data, err := json.Marshal(canonicalize(v))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func canonicalize(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		ordered := make([]interface{}, 0, len(keys)*2)
+		for _, k := range keys {
+			ordered = append(ordered, k, canonicalize(val[k]))
+		}
+		return ordered
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = canonicalize(item)
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+func cacheLookup(key string) (*cacheRecord, bool) {
+	data, err := os.ReadFile(cachePath(key))
+	if err != nil {
+		return nil, false
+	}
+	var record cacheRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, false
+	}
+	return &record, true
+}
+
+func cacheStore(key, method, path string, resp map[string]interface{}) {
+	if err := os.MkdirAll(cacheDir(), 0700); err != nil {
+		return
+	}
+	record := cacheRecord{Key: key, Method: method, Path: path, StoredAt: time.Now(), Response: resp}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return
+	}
+	os.WriteFile(cachePath(key), data, 0600)
+}
+
+func cacheReadEnabled() bool {
+	return offline || cacheMode == "read" || cacheMode == "rw"
+}
+
+func cacheWriteEnabled() bool {
+	return !offline && (cacheMode == "write" || cacheMode == "rw")
+}
+
+func handleCache() {
+	args := flag.Args()
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: mimo cache ls|rm|export <file>|import <file>")
+		os.Exit(1)
+	}
+
+	switch args[1] {
+	case "ls":
+		cacheLs()
+	case "rm":
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, "Usage: mimo cache rm <key>|--all")
+			os.Exit(1)
+		}
+		cacheRm(args[2])
+	case "export":
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, "Usage: mimo cache export <file>")
+			os.Exit(1)
+		}
+		cacheExport(args[2])
+	case "import":
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, "Usage: mimo cache import <file>")
+			os.Exit(1)
+		}
+		cacheImport(args[2])
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown cache subcommand: %s\n", args[1])
+		os.Exit(1)
+	}
+}
+
+func cacheLs() {
+	entries, err := os.ReadDir(cacheDir())
+	if err != nil {
+		fmt.Println("Cache is empty")
+		return
+	}
+
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		key := strings.TrimSuffix(e.Name(), ".json")
+		record, ok := cacheLookup(key)
+		if !ok {
+			continue
+		}
+		fmt.Printf("%s  %-5s %-30s age=%s\n", key, record.Method, record.Path, time.Since(record.StoredAt).Round(time.Second))
+	}
+}
+
+func cacheRm(key string) {
+	if key == "--all" {
+		os.RemoveAll(cacheDir())
+		return
+	}
+	if err := os.Remove(cachePath(key)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func cacheExport(path string) {
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	entries, err := os.ReadDir(cacheDir())
+	if err != nil {
+		return
+	}
+
+	w := bufio.NewWriter(f)
+	defer w.Flush()
+
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cacheDir(), e.Name()))
+		if err != nil {
+			continue
+		}
+		var record cacheRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			continue
+		}
+		line, err := json.Marshal(record)
+		if err != nil {
+			continue
+		}
+		w.Write(line)
+		w.WriteString("\n")
+	}
+}
+
+func cacheImport(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(cacheDir(), 0700); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	imported := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var record cacheRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			continue
+		}
+		out, err := json.MarshalIndent(record, "", "  ")
+		if err != nil {
+			continue
+		}
+		if err := os.WriteFile(cachePath(record.Key), out, 0600); err == nil {
+			imported++
+		}
+	}
+
+	fmt.Printf("Imported %d cache entries\n", imported)
+}
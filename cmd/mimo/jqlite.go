@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jqlite implements the small subset of jq syntax the CLI needs for
+// `--output jq=<expr>`: dot field access, `[]` to iterate an array, `[n]`
+// to index one, and `|` to pipe one expression into the next. It is not a
+// full jq/gojq implementation - just enough for the one-liners people
+// write against ask/tool responses, e.g. `.data[].content` or
+// `.results.episodic[] | .content`.
+
+type jqStep struct {
+	field    string // "" means no field access at this step
+	iterate  bool   // true for a trailing []
+	index    *int   // non-nil for a trailing [n]
+	hasIndex bool
+}
+
+func evalJQ(resp map[string]interface{}, expr string) ([]interface{}, error) {
+	values := []interface{}{interface{}(resp)}
+
+	for _, segment := range strings.Split(expr, "|") {
+		steps, err := parseJQSegment(strings.TrimSpace(segment))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, step := range steps {
+			values = applyJQStep(values, step)
+		}
+	}
+
+	return values, nil
+}
+
+func parseJQSegment(segment string) ([]jqStep, error) {
+	segment = strings.TrimSpace(segment)
+	if segment == "" || segment == "." {
+		return nil, nil
+	}
+	if !strings.HasPrefix(segment, ".") {
+		return nil, fmt.Errorf("invalid jq expression %q: must start with '.'", segment)
+	}
+	segment = strings.TrimPrefix(segment, ".")
+
+	var steps []jqStep
+	for len(segment) > 0 {
+		// Field name up to the next '.' or '['.
+		i := strings.IndexAny(segment, ".[")
+		var field string
+		if i == -1 {
+			field = segment
+			segment = ""
+		} else {
+			field = segment[:i]
+			segment = segment[i:]
+		}
+		if field != "" {
+			steps = append(steps, jqStep{field: field})
+		}
+
+		for strings.HasPrefix(segment, "[") {
+			end := strings.Index(segment, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("invalid jq expression: unterminated '['")
+			}
+			inside := segment[1:end]
+			segment = segment[end+1:]
+
+			if inside == "" {
+				steps = append(steps, jqStep{iterate: true})
+				continue
+			}
+			n, err := strconv.Atoi(inside)
+			if err != nil {
+				return nil, fmt.Errorf("invalid jq index %q", inside)
+			}
+			steps = append(steps, jqStep{hasIndex: true, index: &n})
+		}
+
+		segment = strings.TrimPrefix(segment, ".")
+	}
+
+	return steps, nil
+}
+
+func applyJQStep(values []interface{}, step jqStep) []interface{} {
+	var out []interface{}
+
+	for _, v := range values {
+		switch {
+		case step.field != "":
+			if m, ok := v.(map[string]interface{}); ok {
+				if child, ok := m[step.field]; ok {
+					out = append(out, child)
+				}
+			}
+		case step.iterate:
+			if arr, ok := v.([]interface{}); ok {
+				out = append(out, arr...)
+			}
+		case step.hasIndex:
+			if arr, ok := v.([]interface{}); ok && *step.index >= 0 && *step.index < len(arr) {
+				out = append(out, arr[*step.index])
+			}
+		}
+	}
+
+	return out
+}
@@ -33,6 +33,7 @@ var (
 	sandbox  bool
 	timeout  int
 	verbose  bool
+	stream   bool
 )
 
 func init() {
@@ -41,6 +42,7 @@ func init() {
 	flag.BoolVar(&sandbox, "sandbox", false, "Enable sandbox mode (disables store operations)")
 	flag.IntVar(&timeout, "timeout", 5000, "Request timeout in milliseconds")
 	flag.BoolVar(&verbose, "verbose", false, "Enable verbose output")
+	flag.BoolVar(&stream, "stream", false, "Stream ask responses incrementally (auto-enabled on a TTY)")
 }
 
 func main() {
@@ -58,6 +60,12 @@ func main() {
 		handleAsk()
 	case "run":
 		handleRun()
+	case "repl":
+		handleRepl()
+	case "cache":
+		handleCache()
+	case "batch":
+		handleBatch()
 	case "tools":
 		handleTools()
 	case "health":
@@ -85,6 +93,19 @@ func handleAsk() {
 		"timeout_ms": timeout,
 	}
 
+	// Streaming writes raw deltas straight to stdout as they arrive, so it
+	// can't honor --offline, --cache (no final decoded response to look up
+	// or store), or --output (no response map to run through renderOutput).
+	// Fall through to the normal makeRequest path whenever any of those are
+	// in play.
+	if (stream || isTTY(os.Stdout)) && !offline && cacheMode == "off" && (outputFormat == "" || outputFormat == "text") {
+		if err := streamAsk(payload); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	resp, err := makeRequest("POST", "/v1/mimo/ask", payload)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -193,7 +214,11 @@ func parseToolArgs(args []string) map[string]interface{} {
 	return result
 }
 
-func makeRequest(method, path string, payload interface{}) (map[string]interface{}, error) {
+// buildRequest constructs an HTTP request against the Mimo gateway with the
+// headers common to every call (auth, sandbox, session). Callers that need
+// the raw response - streaming ask in particular - use this directly instead
+// of going through makeRequest.
+func buildRequest(method, path string, payload interface{}) (*http.Request, error) {
 	url := endpoint + path
 
 	var body io.Reader
@@ -217,47 +242,130 @@ func makeRequest(method, path string, payload interface{}) (map[string]interface
 	if sandbox {
 		req.Header.Set("X-Mimo-Sandbox", "true")
 	}
-
-	client := &http.Client{
-		Timeout: time.Duration(timeout) * time.Millisecond,
+	if currentSessionID != "" {
+		req.Header.Set("X-Mimo-Session", currentSessionID)
 	}
 
-	if verbose {
-		fmt.Fprintf(os.Stderr, "[DEBUG] %s %s\n", method, url)
+	return req, nil
+}
+
+func makeRequest(method, path string, payload interface{}) (map[string]interface{}, error) {
+	return makeRequestWithTimeout(method, path, payload, timeout)
+}
+
+// makeRequestWithTimeout is makeRequest with an explicit per-call timeout,
+// so callers that need their own timeout (batch jobs with a "timeout_ms"
+// override) don't have to fork the cache/retry/breaker logic to get it.
+func makeRequestWithTimeout(method, path string, payload interface{}, timeoutMs int) (map[string]interface{}, error) {
+	cacheKey := computeCacheKey(method, path, payload)
+
+	if cacheReadEnabled() {
+		if record, ok := cacheLookup(cacheKey); ok {
+			if offline || time.Since(record.StoredAt) < cacheTTL {
+				return record.Response, nil
+			}
+		} else if offline {
+			return nil, fmt.Errorf("offline mode: no cached response for %s %s", method, path)
+		}
 	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	breaker := breakerFor(hostOf(endpoint))
+	if !breaker.allow() {
+		return nil, fmt.Errorf("circuit breaker open for %s: too many recent failures", hostOf(endpoint))
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	idempotencyKey := ""
+	if isIdempotencyEligible(method, path) {
+		idempotencyKey = newIdempotencyKey()
 	}
 
-	if verbose {
-		fmt.Fprintf(os.Stderr, "[DEBUG] Response: %s\n", string(respBody))
+	maxAttempts := retries + 1
+	if noRetry {
+		maxAttempts = 1
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	client := &http.Client{
+		Timeout: time.Duration(timeoutMs) * time.Millisecond,
 	}
 
-	if resp.StatusCode >= 400 {
-		errMsg := "unknown error"
-		if e, ok := result["error"].(string); ok {
-			errMsg = e
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff(attempt - 1))
 		}
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errMsg)
+
+		req, err := buildRequest(method, path, payload)
+		if err != nil {
+			return nil, err
+		}
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+
+		if verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] %s %s (attempt %d/%d)\n", method, req.URL.String(), attempt+1, maxAttempts)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("request failed: %w", err)
+			breaker.recordFailure()
+			continue
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response: %w", err)
+			breaker.recordFailure()
+			continue
+		}
+
+		if verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Response: %s\n", string(respBody))
+		}
+
+		if isRetryableStatus(resp.StatusCode) {
+			lastErr = fmt.Errorf("API error (%d)", resp.StatusCode)
+			breaker.recordFailure()
+			continue
+		}
+
+		// Any non-5xx answer shows the gateway is up, so it closes the breaker
+		// (and ends a half-open probe) even when the call itself then fails.
+		breaker.recordSuccess()
+
+		var result map[string]interface{}
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if resp.StatusCode >= 400 {
+			errMsg := "unknown error"
+			if e, ok := result["error"].(string); ok {
+				errMsg = e
+			}
+			// A 4xx is a client error (bad request, bad auth, ...) that retrying
+			// or tripping the breaker would never fix, so it doesn't count
+			// against the breaker - only isRetryableStatus (5xx) and transport
+			// errors do, above.
+			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errMsg)
+		}
+
+		if cacheWriteEnabled() {
+			cacheStore(cacheKey, method, path, result)
+		}
+		return result, nil
 	}
 
-	return result, nil
+	return nil, lastErr
 }
 
 func printAskResponse(resp map[string]interface{}) {
+	if renderOutput(resp) {
+		return
+	}
+
 	// Try to get synthesis first (most useful for piping)
 	if synthesis, ok := resp["synthesis"].(string); ok && synthesis != "" {
 		fmt.Println(synthesis)
@@ -287,6 +395,10 @@ func printAskResponse(resp map[string]interface{}) {
 }
 
 func printToolResponse(resp map[string]interface{}) {
+	if renderOutput(resp) {
+		return
+	}
+
 	if data, ok := resp["data"]; ok {
 		// Pretty print for complex data
 		switch v := data.(type) {
@@ -319,6 +431,9 @@ USAGE:
 COMMANDS:
     ask <query>             Query the Meta-Cognitive Router
     run <tool> [--args]     Execute a specific tool
+    repl                    Open an interactive shell with history and context
+    cache ls|rm|export|import  Inspect and seed the local response cache
+    batch <file.ndjson|->   Run a newline-delimited batch of ask/run jobs
     tools                   List available tools
     health                  Check system health
     version                 Show version
@@ -340,12 +455,32 @@ EXAMPLES:
     # Sandbox mode (safe for untrusted scripts)
     mimo --sandbox ask "What are the best practices for error handling?"
 
+    # Filter a tool response for xargs/awk pipelines (options go before the command)
+    mimo -o 'jq=.data[].content' run search_vibes --query x
+
+    # Seed a cache fixture once, then replay it with no live gateway
+    mimo --cache write ask "What are the best practices for error handling?"
+    mimo --offline ask "What are the best practices for error handling?"
+
+    # Batch-ingest a directory of files concurrently
+    find . -name '*.md' | jq -R '{cmd:"run",tool:"ingest",arguments:{path:.}}' | mimo batch -
+
 OPTIONS:
     --api-key <key>     API key (or set MIMO_API_KEY env var)
     --endpoint <url>    Mimo endpoint (default: http://localhost:4000)
     --sandbox           Disable write operations
     --timeout <ms>      Request timeout (default: 5000)
     --verbose           Enable debug output
+    --stream            Stream ask responses incrementally (auto-enabled on a TTY)
+    --output, -o <fmt>  Output format: text,json,ndjson,yaml,table,jq=<expr>
+    --retries <n>       Max retries on 5xx/network errors (default: 3)
+    --retry-max-wait    Cap on backoff wait between retries, in ms (default: 4000)
+    --no-retry          Disable retries entirely
+    --cache <mode>      Response cache mode: off,read,write,rw (default: off)
+    --cache-ttl <dur>   Max age of a cached response (default: 10m)
+    --offline           Cache-only mode; error on a cache miss
+    --parallel <n>      Concurrent workers for "batch" (default: 4)
+    --fail-fast         Stop dispatching new batch jobs after the first error
 
 ENVIRONMENT:
     MIMO_API_KEY        API key for authentication
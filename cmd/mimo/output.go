@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// outputFormat holds the raw --output value: one of text, json, ndjson,
+// yaml, table, or jq=<expr>. It is consulted by renderOutput before
+// printAskResponse/printToolResponse fall back to their plain-text
+// rendering, so every response-printing path gets the same formats.
+var outputFormat string
+
+func init() {
+	flag.StringVar(&outputFormat, "output", "text", "Output format: text,json,ndjson,yaml,table,jq=<expr>")
+	flag.StringVar(&outputFormat, "o", "text", "Shorthand for --output")
+}
+
+// renderOutput prints resp in the format requested by --output and reports
+// whether it handled the response (false means "text" or an unrecognised
+// format, fall back to the caller's own rendering).
+func renderOutput(resp map[string]interface{}) bool {
+	switch {
+	case outputFormat == "" || outputFormat == "text":
+		return false
+	case outputFormat == "json":
+		printJSON(resp)
+	case outputFormat == "ndjson":
+		printNDJSON(extractItems(resp))
+	case outputFormat == "yaml":
+		fmt.Print(toYAML(resp, 0))
+	case outputFormat == "table":
+		printTable(extractItems(resp))
+	case strings.HasPrefix(outputFormat, "jq="):
+		printJQ(resp, strings.TrimPrefix(outputFormat, "jq="))
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown output format: %s\n", outputFormat)
+		return false
+	}
+	return true
+}
+
+func printJSON(v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to marshal JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+func printNDJSON(items []interface{}) {
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		fmt.Println(string(data))
+	}
+}
+
+func printJQ(resp map[string]interface{}, expr string) {
+	results, err := evalJQ(resp, expr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+	for _, r := range results {
+		data, err := json.Marshal(r)
+		if err != nil {
+			continue
+		}
+		fmt.Println(string(data))
+	}
+}
+
+// extractItems finds the list-shaped part of a response for ndjson/table
+// rendering: resp["data"], then resp["results"]["episodic"], then resp
+// itself as a single-item list.
+func extractItems(resp map[string]interface{}) []interface{} {
+	if data, ok := resp["data"]; ok {
+		if items, ok := data.([]interface{}); ok {
+			return items
+		}
+		return []interface{}{data}
+	}
+
+	if results, ok := resp["results"].(map[string]interface{}); ok {
+		if episodic, ok := results["episodic"].([]interface{}); ok {
+			return episodic
+		}
+	}
+
+	return []interface{}{resp}
+}
+
+// printTable renders items as fixed-width columns, kubectl-get style: column
+// headers are the union of keys across all rows, sorted for stable output.
+func printTable(items []interface{}) {
+	if len(items) == 0 {
+		fmt.Println("No results")
+		return
+	}
+
+	keySet := make(map[string]bool)
+	rows := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{"value": item}
+		}
+		rows = append(rows, m)
+		for k := range m {
+			keySet[k] = true
+		}
+	}
+
+	columns := make([]string, 0, len(keySet))
+	for k := range keySet {
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
+
+	widths := make([]int, len(columns))
+	for i, col := range columns {
+		widths[i] = len(strings.ToUpper(col))
+		for _, row := range rows {
+			if w := len(cellString(row[col])); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	printRow := func(cells []string) {
+		parts := make([]string, len(cells))
+		for i, c := range cells {
+			parts[i] = fmt.Sprintf("%-*s", widths[i], c)
+		}
+		fmt.Println(strings.Join(parts, "  "))
+	}
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = strings.ToUpper(col)
+	}
+	printRow(header)
+
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, col := range columns {
+			cells[i] = cellString(row[col])
+		}
+		printRow(cells)
+	}
+}
+
+func cellString(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// toYAML is a small recursive encoder covering the JSON shapes the gateway
+// returns (maps, slices, strings, numbers, bools, nil). It is not a general
+// YAML library - just enough to give --output yaml readable, valid output
+// for these responses.
+func toYAML(v interface{}, indent int) string {
+	pad := strings.Repeat("  ", indent)
+	var b strings.Builder
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			return pad + "{}\n"
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := val[k]
+			switch child.(type) {
+			case map[string]interface{}, []interface{}:
+				b.WriteString(fmt.Sprintf("%s%s:\n", pad, k))
+				b.WriteString(toYAML(child, indent+1))
+			default:
+				b.WriteString(fmt.Sprintf("%s%s: %s\n", pad, k, yamlScalar(child)))
+			}
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			return pad + "[]\n"
+		}
+		for _, item := range val {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				nested := toYAML(item, indent+1)
+				lines := strings.SplitN(nested, "\n", 2)
+				b.WriteString(fmt.Sprintf("%s- %s\n", pad, strings.TrimSpace(lines[0])))
+				if len(lines) > 1 {
+					b.WriteString(lines[1])
+				}
+			default:
+				b.WriteString(fmt.Sprintf("%s- %s\n", pad, yamlScalar(item)))
+			}
+		}
+	default:
+		b.WriteString(fmt.Sprintf("%s%s\n", pad, yamlScalar(val)))
+	}
+
+	return b.String()
+}
+
+func yamlScalar(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	if s, ok := v.(string); ok {
+		if s == "" || strings.ContainsAny(s, ":#{}[]&*!|>'\"%@`\n\r\t") ||
+			strings.TrimSpace(s) != s || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") {
+			return fmt.Sprintf("%q", s)
+		}
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
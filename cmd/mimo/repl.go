@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// currentSessionID, when non-empty, is attached as an X-Mimo-Session header
+// on every request made by makeRequest. The REPL is the only caller that
+// sets it today, so that multi-turn asks issued from the shell land in the
+// same conversation on the gateway side.
+var currentSessionID string
+
+func handleRepl() {
+	historyPath := historyFilePath()
+	history := loadHistory(historyPath)
+	tools := fetchToolNamesForCompletion()
+
+	fmt.Printf("mimo repl %s - type :help for commands, :quit to exit\n", version)
+
+	editor := newLineEditor(history, tools)
+	var sessionIDs []string
+
+	for {
+		line, err := editor.readLine("mimo> ")
+		if err != nil {
+			break
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		history = append(history, line)
+		appendHistory(historyPath, line)
+
+		switch {
+		case line == ":quit" || line == ":exit":
+			return
+		case line == ":help":
+			printReplHelp()
+		case line == ":context":
+			printReplContext(sessionIDs)
+		case strings.HasPrefix(line, ":set "):
+			handleReplSet(strings.TrimPrefix(line, ":set "))
+		case strings.HasPrefix(line, ":run "):
+			handleReplRun(strings.TrimPrefix(line, ":run "))
+		case strings.HasPrefix(line, ":"):
+			fmt.Fprintf(os.Stderr, "Unknown repl command: %s (try :help)\n", line)
+		default:
+			sessionID := replAsk(line)
+			if sessionID != "" {
+				currentSessionID = sessionID
+				sessionIDs = append(sessionIDs, sessionID)
+			}
+		}
+	}
+}
+
+func replAsk(query string) string {
+	payload := map[string]interface{}{
+		"query":      query,
+		"timeout_ms": timeout,
+	}
+
+	resp, err := makeRequest("POST", "/v1/mimo/ask", payload)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ""
+	}
+
+	printAskResponse(resp)
+
+	if sid, ok := resp["session_id"].(string); ok {
+		return sid
+	}
+	return ""
+}
+
+func handleReplRun(rest string) {
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: :run <tool> [--arg value...]")
+		return
+	}
+
+	tool := fields[0]
+	arguments := parseToolArgs(fields[1:])
+
+	payload := map[string]interface{}{
+		"tool":      tool,
+		"arguments": arguments,
+	}
+
+	resp, err := makeRequest("POST", "/v1/mimo/tool", payload)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+
+	printToolResponse(resp)
+}
+
+func handleReplSet(rest string) {
+	fields := strings.Fields(rest)
+	if len(fields) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: :set <key> <value>")
+		return
+	}
+
+	switch fields[0] {
+	case "timeout":
+		ms, err := strconv.Atoi(fields[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid timeout: %v\n", err)
+			return
+		}
+		timeout = ms
+	case "verbose":
+		verbose = fields[1] == "true"
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown setting: %s\n", fields[0])
+	}
+}
+
+func printReplContext(sessionIDs []string) {
+	if len(sessionIDs) == 0 {
+		fmt.Println("No conversation context yet - ask something first")
+		return
+	}
+	fmt.Println("Conversation context (session_id per turn):")
+	for i, sid := range sessionIDs {
+		fmt.Printf("  %d: %s\n", i+1, sid)
+	}
+}
+
+func printReplHelp() {
+	fmt.Println(`REPL commands:
+  <text>                  Send <text> as an ask query
+  :run <tool> [--args]    Invoke a tool directly
+  :set timeout <ms>       Change the request timeout live
+  :set verbose <bool>     Toggle verbose output live
+  :context                Show accumulated session IDs for this conversation
+  :help                   Show this help
+  :quit / :exit           Leave the repl`)
+}
+
+func historyFilePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".mimo_history"
+	}
+	return filepath.Join(home, ".mimo_history")
+}
+
+func loadHistory(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var history []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			history = append(history, line)
+		}
+	}
+	return history
+}
+
+func appendHistory(path, line string) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, line)
+}
+
+// fetchToolNamesForCompletion fetches the tool list once at repl startup so
+// ":run <tab>" can complete against real tool names. Failures are silent -
+// completion just falls back to an empty list.
+func fetchToolNamesForCompletion() []string {
+	resp, err := makeRequest("GET", "/v1/mimo/tools", nil)
+	if err != nil {
+		return nil
+	}
+
+	tools, ok := resp["tools"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(tools))
+	for _, t := range tools {
+		if m, ok := t.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var (
+	retries      int
+	retryMaxWait int
+	noRetry      bool
+)
+
+func init() {
+	flag.IntVar(&retries, "retries", 3, "Max retries on 5xx/network errors")
+	flag.IntVar(&retryMaxWait, "retry-max-wait", 4000, "Cap on backoff wait between retries, in milliseconds")
+	flag.BoolVar(&noRetry, "no-retry", false, "Disable retries entirely")
+}
+
+const (
+	breakerFailureThreshold = 5
+	breakerCooldown         = 30 * time.Second
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker tracks consecutive failures for one gateway host. It opens
+// after breakerFailureThreshold consecutive failures, refuses calls for
+// breakerCooldown, then lets exactly one half-open probe through; success
+// closes it again, failure re-opens it.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	state            breakerState
+	failures         int
+	openedAt         time.Time
+	halfOpenInFlight bool
+}
+
+var breakers = struct {
+	mu     sync.Mutex
+	byHost map[string]*circuitBreaker
+}{byHost: make(map[string]*circuitBreaker)}
+
+func breakerFor(host string) *circuitBreaker {
+	breakers.mu.Lock()
+	defer breakers.mu.Unlock()
+
+	b, ok := breakers.byHost[host]
+	if !ok {
+		b = &circuitBreaker{}
+		breakers.byHost[host] = b
+	}
+	return b
+}
+
+// allow reports whether a request may proceed, transitioning open -> half-open
+// once the cooldown has elapsed.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerClosed:
+		return true
+	case breakerOpen:
+		if time.Since(b.openedAt) < breakerCooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.halfOpenInFlight = true
+		return true
+	case breakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.state = breakerClosed
+	b.halfOpenInFlight = false
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		b.halfOpenInFlight = false
+		return
+	}
+
+	b.failures++
+	if b.failures >= breakerFailureThreshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// backoff returns a jittered exponential delay for the given attempt (0-based),
+// capped at retryMaxWait milliseconds.
+func backoff(attempt int) time.Duration {
+	base := 200 * math.Pow(2, float64(attempt))
+	capped := math.Min(base, float64(retryMaxWait))
+
+	jitter, err := rand.Int(rand.Reader, big.NewInt(int64(capped)+1))
+	wait := capped
+	if err == nil {
+		wait = float64(jitter.Int64())
+	}
+	return time.Duration(wait) * time.Millisecond
+}
+
+// isIdempotencyEligible reports whether path should get an Idempotency-Key
+// header so retries don't double-store on the gateway.
+func isIdempotencyEligible(method, path string) bool {
+	return method == http.MethodPost && (path == "/v1/mimo/tool" || path == "/v1/mimo/ask")
+}
+
+func newIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func hostOf(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	return u.Host
+}
+
+func isRetryableStatus(code int) bool {
+	return code >= 500
+}
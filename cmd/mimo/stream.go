@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+// streamAsk issues the ask request negotiating a streaming response and
+// writes each record's "delta" text to stdout as it arrives; records with
+// no string "delta" field print nothing. It
+// falls back to printAskResponse's JSON path when the gateway answers with
+// a plain application/json body instead of ndjson/sse.
+func streamAsk(payload map[string]interface{}) error {
+	breaker := breakerFor(hostOf(endpoint))
+	if !breaker.allow() {
+		return fmt.Errorf("circuit breaker open for %s: too many recent failures", hostOf(endpoint))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+	go func() {
+		if _, ok := <-sigCh; ok {
+			cancel()
+		}
+	}()
+
+	req, err := buildRequest("POST", "/v1/mimo/ask", payload)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/x-ndjson, text/event-stream")
+	req.Header.Set("Idempotency-Key", newIdempotencyKey())
+
+	client := &http.Client{
+		Timeout: time.Duration(timeout) * time.Millisecond,
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] POST %s (streaming)\n", req.URL.String())
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		breaker.recordFailure()
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if isRetryableStatus(resp.StatusCode) {
+		breaker.recordFailure()
+		return fmt.Errorf("API error (%d)", resp.StatusCode)
+	}
+	breaker.recordSuccess()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("API error (%d)", resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "application/x-ndjson"):
+		return streamNDJSON(resp.Body)
+	case strings.Contains(contentType, "text/event-stream"):
+		return streamSSE(resp.Body)
+	default:
+		var result map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		printAskResponse(result)
+		return nil
+	}
+}
+
+// streamNDJSON prints each line's "delta" field unbuffered as it arrives.
+func streamNDJSON(body io.Reader) error {
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var record map[string]interface{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			continue
+		}
+
+		if delta, ok := record["delta"].(string); ok {
+			fmt.Print(delta)
+			os.Stdout.Sync()
+		}
+	}
+	fmt.Println()
+	return scanner.Err()
+}
+
+// streamSSE parses "data: " events separated by blank lines and prints each
+// decoded delta unbuffered.
+func streamSSE(body io.Reader) error {
+	scanner := bufio.NewScanner(body)
+	var event strings.Builder
+
+	flush := func() {
+		data := strings.TrimSpace(event.String())
+		event.Reset()
+		if data == "" || data == "[DONE]" {
+			return
+		}
+
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return
+		}
+		if delta, ok := record["delta"].(string); ok {
+			fmt.Print(delta)
+			os.Stdout.Sync()
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(line, "data:") {
+			event.WriteString(strings.TrimPrefix(line, "data:"))
+			event.WriteString(" ")
+		}
+	}
+	flush()
+	fmt.Println()
+	return scanner.Err()
+}
+
+func isTTY(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
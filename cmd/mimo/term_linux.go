@@ -0,0 +1,188 @@
+//go:build linux
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// lineEditor is a minimal raw-mode line editor: up/down recall history,
+// tab completes the word after ":run ", left/right move the cursor, and
+// backspace/ctrl-c/ctrl-d behave as expected. It intentionally does not
+// try to be a full readline - just enough for a workstation repl.
+type lineEditor struct {
+	history     []string
+	completions []string
+	plainReader *bufio.Reader
+}
+
+func newLineEditor(history, completions []string) *lineEditor {
+	return &lineEditor{history: history, completions: completions}
+}
+
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	old, err := termMakeRaw(fd)
+	if err != nil {
+		// Not a terminal (e.g. piped input) - fall back to plain reads.
+		return e.readLinePlain(prompt)
+	}
+	defer termRestore(fd, old)
+
+	fmt.Print(prompt)
+
+	var buf []rune
+	cursor := 0
+	historyPos := len(e.history)
+
+	redraw := func() {
+		fmt.Print("\r\x1b[K", prompt, string(buf))
+		if back := len(buf) - cursor; back > 0 {
+			fmt.Printf("\x1b[%dD", back)
+		}
+	}
+
+	reader := make([]byte, 3)
+	for {
+		n, err := os.Stdin.Read(reader)
+		if err != nil || n == 0 {
+			return "", fmt.Errorf("eof")
+		}
+
+		switch {
+		case reader[0] == '\r' || reader[0] == '\n':
+			fmt.Print("\r\n")
+			if line := strings.TrimSpace(string(buf)); line != "" {
+				e.history = append(e.history, line)
+			}
+			return string(buf), nil
+		case reader[0] == 3: // Ctrl-C
+			fmt.Print("\r\n")
+			return "", fmt.Errorf("interrupted")
+		case reader[0] == 4: // Ctrl-D
+			if len(buf) == 0 {
+				return "", fmt.Errorf("eof")
+			}
+		case reader[0] == 127 || reader[0] == 8: // backspace
+			if cursor > 0 {
+				buf = append(buf[:cursor-1], buf[cursor:]...)
+				cursor--
+				redraw()
+			}
+		case reader[0] == '\t':
+			buf, cursor = e.complete(buf, cursor)
+			redraw()
+		case reader[0] == 0x1b && n >= 3 && reader[1] == '[':
+			switch reader[2] {
+			case 'A': // up
+				if historyPos > 0 {
+					historyPos--
+					buf = []rune(e.history[historyPos])
+					cursor = len(buf)
+					redraw()
+				}
+			case 'B': // down
+				if historyPos < len(e.history)-1 {
+					historyPos++
+					buf = []rune(e.history[historyPos])
+					cursor = len(buf)
+				} else {
+					historyPos = len(e.history)
+					buf = nil
+					cursor = 0
+				}
+				redraw()
+			case 'C': // right
+				if cursor < len(buf) {
+					cursor++
+					redraw()
+				}
+			case 'D': // left
+				if cursor > 0 {
+					cursor--
+					redraw()
+				}
+			}
+		default:
+			buf = append(buf[:cursor], append([]rune{rune(reader[0])}, buf[cursor:]...)...)
+			cursor++
+			redraw()
+		}
+	}
+}
+
+// complete replaces the line with ":run <name>" for the first entry of
+// e.completions that starts with all of the text after ":run ". Only the
+// tool name completes: once arguments follow it, nothing matches and the
+// line is left as it is.
+func (e *lineEditor) complete(buf []rune, cursor int) ([]rune, int) {
+	line := string(buf)
+	if !strings.HasPrefix(line, ":run ") || len(e.completions) == 0 {
+		return buf, cursor
+	}
+
+	prefix := strings.TrimPrefix(line, ":run ")
+	for _, name := range e.completions {
+		if strings.HasPrefix(name, prefix) {
+			completed := []rune(":run " + name)
+			return completed, len(completed)
+		}
+	}
+	return buf, cursor
+}
+
+// readLinePlain reads one full line (not just one whitespace-delimited
+// token) for piped/non-TTY stdin, same as term_other.go's fallback. The
+// reader is kept on the editor so buffered bytes survive across calls.
+func (e *lineEditor) readLinePlain(prompt string) (string, error) {
+	fmt.Print(prompt)
+	if e.plainReader == nil {
+		e.plainReader = bufio.NewReader(os.Stdin)
+	}
+	line, err := e.plainReader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// The termios layout below matches struct termios on linux/amd64 and arm64.
+type termios struct {
+	Iflag, Oflag, Cflag, Lflag uint32
+	Line                       byte
+	Cc                         [32]byte
+	Ispeed, Ospeed             uint32
+}
+
+const (
+	tcgets = 0x5401
+	tcsets = 0x5402
+)
+
+func termMakeRaw(fd int) (*termios, error) {
+	var old termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), tcgets, uintptr(unsafe.Pointer(&old))); errno != 0 {
+		return nil, errno
+	}
+
+	raw := old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ISIG
+	raw.Iflag &^= syscall.IXON
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), tcsets, uintptr(unsafe.Pointer(&raw))); errno != 0 {
+		return nil, errno
+	}
+	return &old, nil
+}
+
+func termRestore(fd int, old *termios) {
+	syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), tcsets, uintptr(unsafe.Pointer(old)))
+}
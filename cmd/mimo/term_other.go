@@ -0,0 +1,32 @@
+//go:build !linux
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// lineEditor on non-Linux platforms falls back to plain buffered reads:
+// no arrow-key history recall or tab completion, but history is still
+// loaded/appended to ~/.mimo_history so the file stays portable across
+// machines.
+type lineEditor struct {
+	scanner *bufio.Scanner
+}
+
+func newLineEditor(history, completions []string) *lineEditor {
+	return &lineEditor{scanner: bufio.NewScanner(os.Stdin)}
+}
+
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	fmt.Print(prompt)
+	if !e.scanner.Scan() {
+		if err := e.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("eof")
+	}
+	return e.scanner.Text(), nil
+}